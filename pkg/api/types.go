@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StrategyName is the name of a descheduler strategy, as referenced from the descheduler policy.
+type StrategyName string
+
+// DeschedulerStrategy configures a single descheduler strategy.
+type DeschedulerStrategy struct {
+	Enabled bool
+	Weight  int
+	Params  *StrategyParameters
+}
+
+// StrategyParameters holds the parameters common to the strategies in this package.
+type StrategyParameters struct {
+	LabelSelector              *metav1.LabelSelector
+	ThresholdPriority          *int32
+	ThresholdPriorityClassName string
+	Namespaces                 *Namespaces
+
+	// IgnorePodAffinityNamespaceSelector, when true, disables resolving a PodAffinityTerm's
+	// NamespaceSelector against the cluster's namespaces, falling back to Namespaces only. Clusters
+	// running an API server older than 1.21 (before NamespaceSelector existed) should set this to true.
+	IgnorePodAffinityNamespaceSelector bool
+
+	// MinimizeEvictions, when true, resolves inter-pod anti-affinity conflicts by computing a minimum
+	// (or, for large conflict graphs, approximately minimum) vertex cover of the conflicting pods instead
+	// of greedily evicting the lowest-priority violator on each pass. This can evict fewer pods overall
+	// when conflicts overlap, at the cost of building and solving one conflict graph over every pod the
+	// strategy considers across all nodes.
+	MinimizeEvictions bool
+
+	// PreferredAntiAffinityWeightThreshold opts a pod into eviction based on violated
+	// PreferredDuringSchedulingIgnoredDuringExecution anti-affinity terms: if the sum of the weights
+	// (1-100 each) of a pod's violated preferred terms meets or exceeds this threshold, the pod is
+	// treated as evictable just as if it violated a required term. Must be between 1 and 100 if set.
+	PreferredAntiAffinityWeightThreshold *int32
+}
+
+// Namespaces carries the namespace include/exclude filter for a strategy; at most one of Include or
+// Exclude may be set.
+type Namespaces struct {
+	Include []string
+	Exclude []string
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/utils"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+func validateRemovePodsViolatingInterPodAffinityParams(params *api.StrategyParameters) error {
+	if params == nil {
+		return nil
+	}
+
+	// At most one of include/exclude can be set
+	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
+		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	}
+	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
+		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
+	}
+
+	return nil
+}
+
+// RemovePodsViolatingInterPodAffinity evicts pods on the node which are not satisfying the required
+// inter-pod affinity rules of the pod itself. This typically happens when the pods an anchor pod was
+// scheduled near have since been deleted, leaving the remaining pods stranded on nodes where the
+// scheduler would no longer have placed them, yet which it will not rebalance on its own.
+func RemovePodsViolatingInterPodAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, evictorFilter *evictions.EvictorFilter, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) {
+	if err := validateRemovePodsViolatingInterPodAffinityParams(strategy.Params); err != nil {
+		klog.ErrorS(err, "Invalid RemovePodsViolatingInterPodAffinity parameters")
+		return
+	}
+
+	var includedNamespaces, excludedNamespaces sets.String
+	var labelSelector *metav1.LabelSelector
+	ignorePodAffinityNamespaceSelector := false
+	if strategy.Params != nil {
+		if strategy.Params.Namespaces != nil {
+			includedNamespaces = sets.NewString(strategy.Params.Namespaces.Include...)
+			excludedNamespaces = sets.NewString(strategy.Params.Namespaces.Exclude...)
+		}
+		labelSelector = strategy.Params.LabelSelector
+		ignorePodAffinityNamespaceSelector = strategy.Params.IgnorePodAffinityNamespaceSelector
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(labelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		klog.ErrorS(err, "Error initializing pod filter function")
+		return
+	}
+
+	namespaceSelectorCache := map[string]sets.String{}
+
+	// checkPodAffinityUnsatisfied needs visibility of every pod in the cluster, not just the pods on the
+	// node currently being processed, since a satisfying pod may live in the same topology domain on a
+	// different node.
+	allPods, err := listPodsAcrossNodes(nodes, getPodsAssignedToNode, podFilter)
+	if err != nil {
+		klog.ErrorS(err, "Error listing all pods")
+		return
+	}
+
+loop:
+	for _, node := range nodes {
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		pods := podsOnNode(node.Name, allPods)
+		// sort the evictable Pods based on priority, if there are multiple pods with same priority, they are sorted based on QoS tiers.
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+		totalPods := len(pods)
+		for i := 0; i < totalPods; i++ {
+			if checkPodAffinityUnsatisfied(ctx, client, pods[i], allPods, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache) && evictorFilter.Filter(pods[i]) {
+				if podEvictor.EvictPod(ctx, pods[i]) {
+					evicted := pods[i]
+					pods = append(pods[:i], pods[i+1:]...)
+					allPods = removePod(allPods, evicted)
+					i--
+					totalPods--
+				}
+			}
+			if podEvictor.NodeLimitExceeded(node) {
+				continue loop
+			}
+		}
+	}
+}
+
+// checkPodAffinityUnsatisfied checks whether pod's required inter-pod affinity terms are unsatisfied,
+// i.e. whether some required term has no matching pod within its TopologyKey domain. allPods must span
+// every node under consideration, since the satisfying pod for a given term may not be on pod's own node.
+func checkPodAffinityUnsatisfied(ctx context.Context, client clientset.Interface, pod *v1.Pod, allPods []*v1.Pod, nodes []*v1.Node, ignorePodAffinityNamespaceSelector bool, namespaceSelectorCache map[string]sets.String) bool {
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.PodAffinity == nil {
+		return false
+	}
+
+	terms := getPodAffinityTerms(affinity.PodAffinity)
+	if len(terms) == 0 {
+		return false
+	}
+
+	podTopology := nodeTopologyLabels(pod.Spec.NodeName, nodes)
+	if podTopology == nil {
+		return false
+	}
+
+	for _, term := range terms {
+		hasNamespaceSelector := term.NamespaceSelector != nil && !ignorePodAffinityNamespaceSelector
+		var selectorNamespaces sets.String
+		if hasNamespaceSelector {
+			var err error
+			selectorNamespaces, err = resolveNamespacesBySelector(ctx, client, term.NamespaceSelector, namespaceSelectorCache)
+			if err != nil {
+				klog.ErrorS(err, "Unable to resolve term.NamespaceSelector")
+				return false
+			}
+		}
+		namespaces := utils.GetNamespacesFromPodAffinityTerm(pod, &term, selectorNamespaces, hasNamespaceSelector)
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			klog.ErrorS(err, "Unable to convert LabelSelector into Selector")
+			return false
+		}
+
+		topologyValue, ok := podTopology[term.TopologyKey]
+		if !ok {
+			return true
+		}
+
+		satisfied := false
+		for _, candidate := range allPods {
+			if candidate.Namespace == pod.Namespace && candidate.Name == pod.Name {
+				continue
+			}
+			candidateTopology := nodeTopologyLabels(candidate.Spec.NodeName, nodes)
+			if candidateTopology[term.TopologyKey] != topologyValue {
+				continue
+			}
+			if utils.PodMatchesTermsNamespaceAndSelector(candidate, namespaces, selector) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// getPodAffinityTerms gets the required affinity terms for the given pod.
+func getPodAffinityTerms(podAffinity *v1.PodAffinity) (terms []v1.PodAffinityTerm) {
+	if podAffinity != nil {
+		if len(podAffinity.RequiredDuringSchedulingIgnoredDuringExecution) != 0 {
+			terms = podAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		}
+	}
+	return terms
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func affinityTestPod(name, namespace, nodeName string, labels map[string]string, affinityLabels map[string]string, topologyKey string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec:       v1.PodSpec{NodeName: nodeName},
+	}
+	if affinityLabels != nil {
+		pod.Spec.Affinity = &v1.Affinity{
+			PodAffinity: &v1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: affinityLabels},
+						TopologyKey:   topologyKey,
+					},
+				},
+			},
+		}
+	}
+	return pod
+}
+
+// TestCheckPodAffinityUnsatisfiedCrossNode verifies that a required affinity term is considered satisfied
+// by a matching pod elsewhere in the same topology domain, even when that pod is on a different node than
+// the one being evaluated - the cross-node visibility allPods exists to provide.
+func TestCheckPodAffinityUnsatisfiedCrossNode(t *testing.T) {
+	tests := []struct {
+		name        string
+		anchorZone  string
+		matchZone   string
+		wantUnsat   bool
+		includeMate bool
+	}{
+		{
+			name:        "matching pod in same zone on a different node: satisfied",
+			anchorZone:  "zone-a",
+			matchZone:   "zone-a",
+			includeMate: true,
+			wantUnsat:   false,
+		},
+		{
+			name:        "matching pod in a different zone: unsatisfied",
+			anchorZone:  "zone-a",
+			matchZone:   "zone-b",
+			includeMate: true,
+			wantUnsat:   true,
+		},
+		{
+			name:        "no matching pod anywhere: unsatisfied",
+			anchorZone:  "zone-a",
+			matchZone:   "zone-a",
+			includeMate: false,
+			wantUnsat:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nodeAnchor := zoneTestNode("node-anchor", tc.anchorZone)
+			nodeMate := zoneTestNode("node-mate", tc.matchZone)
+			nodes := []*v1.Node{nodeAnchor, nodeMate}
+
+			anchor := affinityTestPod("anchor", "default", "node-anchor", nil, map[string]string{"app": "bar"}, zoneKey)
+			allPods := []*v1.Pod{anchor}
+			if tc.includeMate {
+				mate := affinityTestPod("mate", "default", "node-mate", map[string]string{"app": "bar"}, nil, "")
+				allPods = append(allPods, mate)
+			}
+
+			client := fake.NewSimpleClientset()
+			got := checkPodAffinityUnsatisfied(context.TODO(), client, anchor, allPods, nodes, false, map[string]sets.String{})
+			if got != tc.wantUnsat {
+				t.Errorf("checkPodAffinityUnsatisfied() = %v, want %v", got, tc.wantUnsat)
+			}
+		})
+	}
+}
+
+// TestCheckPodAffinityUnsatisfiedSameNameDifferentNamespace verifies that self-exclusion from the
+// candidate loop matches on namespace+name, not name alone: a distinct pod in another namespace that
+// happens to share pod's name must still be able to satisfy the term, and must not cause pod to skip
+// itself across namespaces.
+func TestCheckPodAffinityUnsatisfiedSameNameDifferentNamespace(t *testing.T) {
+	node := zoneTestNode("node-a", "zone-a")
+	nodes := []*v1.Node{node}
+
+	anchor := affinityTestPod("shared-name", "ns-a", "node-a", nil, map[string]string{"app": "bar"}, zoneKey)
+	// The term must cover ns-b, otherwise it defaults to anchor's own namespace and ns-b pods never match.
+	anchor.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].Namespaces = []string{"ns-b"}
+	samenameOtherNamespace := affinityTestPod("shared-name", "ns-b", "node-a", map[string]string{"app": "bar"}, nil, "")
+	allPods := []*v1.Pod{anchor, samenameOtherNamespace}
+
+	client := fake.NewSimpleClientset()
+	if got := checkPodAffinityUnsatisfied(context.TODO(), client, anchor, allPods, nodes, false, map[string]sets.String{}); got {
+		t.Fatalf("expected the same-named pod in a different namespace to satisfy the term, got unsatisfied")
+	}
+}
+
+// TestCheckPodAffinityUnsatisfiedNamespaceSelector exercises resolveNamespacesBySelector end-to-end:
+// term.NamespaceSelector is resolved against namespaces seeded on a fake clientset, and
+// ignorePodAffinityNamespaceSelector=true is verified to opt back out to the "default to pod's own
+// namespace" behavior instead of consulting the selector at all.
+func TestCheckPodAffinityUnsatisfiedNamespaceSelector(t *testing.T) {
+	node := zoneTestNode("node-a", "zone-a")
+	nodes := []*v1.Node{node}
+
+	anchor := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "anchor", Namespace: "pod-ns"},
+		Spec: v1.PodSpec{
+			NodeName: "node-a",
+			Affinity: &v1.Affinity{
+				PodAffinity: &v1.PodAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+						{
+							LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "bar"}},
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+							TopologyKey:       zoneKey,
+						},
+					},
+				},
+			},
+		},
+	}
+	mate := affinityTestPod("mate", "team-a-ns", "node-a", map[string]string{"app": "bar"}, nil, "")
+	allPods := []*v1.Pod{anchor, mate}
+
+	client := fake.NewSimpleClientset(labeledTestNamespace("team-a-ns", map[string]string{"team": "a"}))
+
+	t.Run("NamespaceSelector resolved against the cluster: satisfied", func(t *testing.T) {
+		if got := checkPodAffinityUnsatisfied(context.TODO(), client, anchor, allPods, nodes, false, map[string]sets.String{}); got {
+			t.Fatalf("expected term to be satisfied once NamespaceSelector resolves to team-a-ns")
+		}
+	})
+
+	t.Run("ignorePodAffinityNamespaceSelector=true: falls back to pod's own namespace, unsatisfied", func(t *testing.T) {
+		if got := checkPodAffinityUnsatisfied(context.TODO(), client, anchor, allPods, nodes, true, map[string]sets.String{}); !got {
+			t.Fatalf("expected term to be unsatisfied once NamespaceSelector is ignored and the term defaults to pod-ns")
+		}
+	})
+}
@@ -19,10 +19,12 @@ package strategies
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/metrics"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 
@@ -44,6 +46,11 @@ func validateRemovePodsViolatingInterPodAntiAffinityParams(params *api.StrategyP
 	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
 		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
 	}
+	if params.PreferredAntiAffinityWeightThreshold != nil {
+		if *params.PreferredAntiAffinityWeightThreshold < 1 || *params.PreferredAntiAffinityWeightThreshold > 100 {
+			return fmt.Errorf("preferredAntiAffinityWeightThreshold must be between 1 and 100")
+		}
+	}
 
 	return nil
 }
@@ -57,12 +64,18 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 
 	var includedNamespaces, excludedNamespaces sets.String
 	var labelSelector *metav1.LabelSelector
+	ignorePodAffinityNamespaceSelector := false
+	minimizeEvictions := false
+	var preferredWeightThreshold *int32
 	if strategy.Params != nil {
 		if strategy.Params.Namespaces != nil {
 			includedNamespaces = sets.NewString(strategy.Params.Namespaces.Include...)
 			excludedNamespaces = sets.NewString(strategy.Params.Namespaces.Exclude...)
 		}
 		labelSelector = strategy.Params.LabelSelector
+		ignorePodAffinityNamespaceSelector = strategy.Params.IgnorePodAffinityNamespaceSelector
+		minimizeEvictions = strategy.Params.MinimizeEvictions
+		preferredWeightThreshold = strategy.Params.PreferredAntiAffinityWeightThreshold
 	}
 
 	podFilter, err := podutil.NewOptions().
@@ -75,23 +88,45 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 		return
 	}
 
+	// namespaceSelectorCache caches the namespaces resolved for a given term.NamespaceSelector so that
+	// repeatedly evaluating the same selector across pods and nodes does not repeatedly list namespaces.
+	namespaceSelectorCache := map[string]sets.String{}
+
+	// allPods spans every node under consideration: an anti-affinity term's TopologyKey may group pods
+	// across node boundaries (e.g. topology.kubernetes.io/zone), so a violation must be checked against
+	// the whole cluster, not just the pods already on pod's own node.
+	allPods, err := listPodsAcrossNodes(nodes, getPodsAssignedToNode, podFilter)
+	if err != nil {
+		klog.ErrorS(err, "Error listing all pods")
+		return
+	}
+
+	if minimizeEvictions {
+		// The conflict graph spans the whole cluster, not a single node: a term's TopologyKey can group
+		// pods across node boundaries, so restricting vertices to one node's pods at a time would miss
+		// conflicts entirely for any TopologyKey other than kubernetes.io/hostname.
+		podutil.SortPodsBasedOnPriorityLowToHigh(allPods)
+		evictMinimumAntiAffinityCover(ctx, client, podEvictor, evictorFilter, nodes, &allPods, ignorePodAffinityNamespaceSelector, namespaceSelectorCache, preferredWeightThreshold)
+		return
+	}
+
 loop:
 	for _, node := range nodes {
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, podFilter)
-		if err != nil {
-			return
-		}
+		pods := podsOnNode(node.Name, allPods)
 		// sort the evictable Pods based on priority, if there are multiple pods with same priority, they are sorted based on QoS tiers.
 		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+
 		totalPods := len(pods)
 		for i := 0; i < totalPods; i++ {
-			if checkPodsWithAntiAffinityExist(pods[i], pods) && evictorFilter.Filter(pods[i]) {
+			if checkPodsWithAntiAffinityExist(ctx, client, pods[i], allPods, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache, preferredWeightThreshold) && evictorFilter.Filter(pods[i]) {
 				if podEvictor.EvictPod(ctx, pods[i]) {
 					// Since the current pod is evicted all other pods which have anti-affinity with this
-					// pod need not be evicted.
-					// Update pods.
+					// pod need not be evicted. Update both the node-local and cluster-wide pod lists so
+					// later nodes in this same pass no longer see the evicted pod as a conflict.
+					evicted := pods[i]
 					pods = append(pods[:i], pods[i+1:]...)
+					allPods = removePod(allPods, evicted)
 					i--
 					totalPods--
 				}
@@ -103,27 +138,246 @@ loop:
 	}
 }
 
-// checkPodsWithAntiAffinityExist checks if there are other pods on the node that the current pod cannot tolerate.
-func checkPodsWithAntiAffinityExist(pod *v1.Pod, pods []*v1.Pod) bool {
-	affinity := pod.Spec.Affinity
-	if affinity != nil && affinity.PodAntiAffinity != nil {
-		for _, term := range getPodAntiAffinityTerms(affinity.PodAntiAffinity) {
-			namespaces := utils.GetNamespacesFromPodAffinityTerm(pod, &term)
-			selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
-			if err != nil {
-				klog.ErrorS(err, "Unable to convert LabelSelector into Selector")
-				return false
-			}
-			for _, existingPod := range pods {
-				if existingPod.Name != pod.Name && utils.PodMatchesTermsNamespaceAndSelector(existingPod, namespaces, selector) {
-					return true
-				}
+// evictMinimumAntiAffinityCover resolves anti-affinity conflicts across the whole cluster by modeling
+// every pod in allPods as a vertex in an undirected graph (edges connect pods that conflict in the relevant
+// topology domain, which may span multiple nodes) and evicting a minimum vertex cover of that graph,
+// rather than greedily evicting the lowest-priority violator on every pass. This can evict strictly fewer
+// pods than the greedy resolution when conflicts overlap (e.g. pods A-B and B-C conflict: evicting only B
+// resolves both). Per-node eviction limits are still enforced per pod's own node: once a node's limit is
+// reached, remaining cover pods on that node are skipped but pods on other nodes still proceed.
+// evictorFilter is applied only at eviction time: cover pods it rejects are skipped, and the conflicts
+// they cover are left unresolved.
+func evictMinimumAntiAffinityCover(ctx context.Context, client clientset.Interface, podEvictor *evictions.PodEvictor, evictorFilter *evictions.EvictorFilter, nodes []*v1.Node, allPods *[]*v1.Pod, ignorePodAffinityNamespaceSelector bool, namespaceSelectorCache map[string]sets.String, preferredWeightThreshold *int32) {
+	// Copy, so that removing evicted pods from *allPods does not shift the vertex indices used by cover.
+	pods := append([]*v1.Pod{}, (*allPods)...)
+	var edges []vertexCoverEdge
+	for i := range pods {
+		for j := i + 1; j < len(pods); j++ {
+			if podsConflict(ctx, client, pods[i], pods[j], nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache, preferredWeightThreshold) {
+				edges = append(edges, vertexCoverEdge{u: i, v: j})
 			}
 		}
 	}
+	if len(edges) == 0 {
+		return
+	}
+
+	cover := minWeightVertexCover(len(pods), edges, func(v int) int64 { return podutil.GetPodPriority(pods[v]) })
+
+	if greedyCount := countGreedyEvictions(ctx, client, pods, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache, preferredWeightThreshold); greedyCount > len(cover) {
+		metrics.MinimizeEvictionsSaved.WithLabelValues("RemovePodsViolatingInterPodAntiAffinity").Add(float64(greedyCount - len(cover)))
+	}
+
+	// Evict lower-priority pods first, consistent with the greedy path's eviction order.
+	sort.Slice(cover, func(i, j int) bool {
+		return podutil.GetPodPriority(pods[cover[i]]) < podutil.GetPodPriority(pods[cover[j]])
+	})
+
+	nodeLimitExceeded := sets.NewString()
+	for _, idx := range cover {
+		pod := pods[idx]
+		if nodeLimitExceeded.Has(pod.Spec.NodeName) || !evictorFilter.Filter(pod) {
+			continue
+		}
+		if podEvictor.EvictPod(ctx, pod) {
+			*allPods = removePod(*allPods, pod)
+		}
+		if node := nodeByName(pod.Spec.NodeName, nodes); node != nil && podEvictor.NodeLimitExceeded(node) {
+			nodeLimitExceeded.Insert(pod.Spec.NodeName)
+		}
+	}
+}
+
+// podsConflict reports whether a and b have an anti-affinity conflict in either direction: a's
+// anti-affinity may be violated by b, or b's by a.
+func podsConflict(ctx context.Context, client clientset.Interface, a, b *v1.Pod, nodes []*v1.Node, ignorePodAffinityNamespaceSelector bool, namespaceSelectorCache map[string]sets.String, preferredWeightThreshold *int32) bool {
+	return checkPodsWithAntiAffinityExist(ctx, client, a, []*v1.Pod{b}, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache, preferredWeightThreshold) ||
+		checkPodsWithAntiAffinityExist(ctx, client, b, []*v1.Pod{a}, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache, preferredWeightThreshold)
+}
+
+// countGreedyEvictions simulates the greedy anti-affinity resolution against the cluster-wide pods slice
+// without evicting anything, purely to report how many evictions MinimizeEvictions saved relative to it.
+func countGreedyEvictions(ctx context.Context, client clientset.Interface, pods []*v1.Pod, nodes []*v1.Node, ignorePodAffinityNamespaceSelector bool, namespaceSelectorCache map[string]sets.String, preferredWeightThreshold *int32) int {
+	remaining := append([]*v1.Pod{}, pods...)
+	count := 0
+	for i := 0; i < len(remaining); i++ {
+		if checkPodsWithAntiAffinityExist(ctx, client, remaining[i], remaining, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache, preferredWeightThreshold) {
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			i--
+			count++
+		}
+	}
+	return count
+}
+
+// checkPodsWithAntiAffinityExist checks if there are other pods in the same topology domain that the
+// current pod cannot tolerate: either a required anti-affinity term is violated, or, when
+// preferredWeightThreshold is non-nil, the sum of the weights of violated preferred anti-affinity terms
+// meets or exceeds the threshold.
+func checkPodsWithAntiAffinityExist(ctx context.Context, client clientset.Interface, pod *v1.Pod, pods []*v1.Pod, nodes []*v1.Node, ignorePodAffinityNamespaceSelector bool, namespaceSelectorCache map[string]sets.String, preferredWeightThreshold *int32) bool {
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.PodAntiAffinity == nil {
+		return false
+	}
+
+	podTopology := nodeTopologyLabels(pod.Spec.NodeName, nodes)
+	if podTopology == nil {
+		return false
+	}
+
+	for _, term := range getPodAntiAffinityTerms(affinity.PodAntiAffinity) {
+		if podViolatesAntiAffinityTerm(ctx, client, pod, term, pods, podTopology, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache) {
+			return true
+		}
+	}
+
+	if preferredWeightThreshold != nil {
+		weightSum := sumViolatedPreferredAntiAffinityWeight(ctx, client, pod, pods, podTopology, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache)
+		if weightSum >= *preferredWeightThreshold {
+			klog.V(1).InfoS("Pod violates preferred anti-affinity terms above threshold", "pod", klog.KObj(pod), "weightSum", weightSum, "threshold", *preferredWeightThreshold)
+			return true
+		}
+	}
 	return false
 }
 
+// podViolatesAntiAffinityTerm reports whether some pod in candidates, other than pod itself, falls
+// within pod's topology domain for term (grouped by the value of term.TopologyKey on their node) and
+// matches term's namespace+selector, i.e. whether term is violated.
+func podViolatesAntiAffinityTerm(ctx context.Context, client clientset.Interface, pod *v1.Pod, term v1.PodAffinityTerm, candidates []*v1.Pod, podTopology map[string]string, nodes []*v1.Node, ignorePodAffinityNamespaceSelector bool, namespaceSelectorCache map[string]sets.String) bool {
+	hasNamespaceSelector := term.NamespaceSelector != nil && !ignorePodAffinityNamespaceSelector
+	var selectorNamespaces sets.String
+	if hasNamespaceSelector {
+		var err error
+		selectorNamespaces, err = resolveNamespacesBySelector(ctx, client, term.NamespaceSelector, namespaceSelectorCache)
+		if err != nil {
+			klog.ErrorS(err, "Unable to resolve term.NamespaceSelector")
+			return false
+		}
+	}
+	namespaces := utils.GetNamespacesFromPodAffinityTerm(pod, &term, selectorNamespaces, hasNamespaceSelector)
+	selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		klog.ErrorS(err, "Unable to convert LabelSelector into Selector")
+		return false
+	}
+
+	topologyValue, ok := podTopology[term.TopologyKey]
+	if !ok {
+		return false
+	}
+
+	for _, existingPod := range candidates {
+		if existingPod.Namespace == pod.Namespace && existingPod.Name == pod.Name {
+			continue
+		}
+		existingPodTopology := nodeTopologyLabels(existingPod.Spec.NodeName, nodes)
+		if existingPodTopology[term.TopologyKey] != topologyValue {
+			continue
+		}
+		if utils.PodMatchesTermsNamespaceAndSelector(existingPod, namespaces, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// sumViolatedPreferredAntiAffinityWeight sums the Weight of every
+// PreferredDuringSchedulingIgnoredDuringExecution term of pod's anti-affinity that is violated by some
+// pod in candidates, using the same topology-aware matching as required terms.
+func sumViolatedPreferredAntiAffinityWeight(ctx context.Context, client clientset.Interface, pod *v1.Pod, candidates []*v1.Pod, podTopology map[string]string, nodes []*v1.Node, ignorePodAffinityNamespaceSelector bool, namespaceSelectorCache map[string]sets.String) int32 {
+	var weightSum int32
+	for _, weighted := range pod.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		if podViolatesAntiAffinityTerm(ctx, client, pod, weighted.PodAffinityTerm, candidates, podTopology, nodes, ignorePodAffinityNamespaceSelector, namespaceSelectorCache) {
+			weightSum += weighted.Weight
+		}
+	}
+	return weightSum
+}
+
+// resolveNamespacesBySelector resolves term.NamespaceSelector against the cluster's namespaces, caching
+// the result by the selector's string representation since the same term is evaluated for every
+// candidate pod on every node.
+func resolveNamespacesBySelector(ctx context.Context, client clientset.Interface, selector *metav1.LabelSelector, cache map[string]sets.String) (sets.String, error) {
+	key := selector.String()
+	if names, ok := cache[key]; ok {
+		return names, nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceList, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	names := sets.NewString()
+	for _, ns := range namespaceList.Items {
+		names.Insert(ns.Name)
+	}
+	cache[key] = names
+	return names, nil
+}
+
+// listPodsAcrossNodes gathers the pods matching podFilter across every node in nodes. Anti/affinity
+// checks need this cluster-wide view rather than a single node's pods, since a term's TopologyKey can
+// group pods across node boundaries (e.g. a zone spans many nodes).
+func listPodsAcrossNodes(nodes []*v1.Node, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, podFilter podutil.FilterFunc) ([]*v1.Pod, error) {
+	var allPods []*v1.Pod
+	for _, node := range nodes {
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, podFilter)
+		if err != nil {
+			return nil, err
+		}
+		allPods = append(allPods, pods...)
+	}
+	return allPods, nil
+}
+
+// podsOnNode filters pods down to those assigned to the node with the given name.
+func podsOnNode(nodeName string, pods []*v1.Pod) []*v1.Pod {
+	var onNode []*v1.Pod
+	for _, pod := range pods {
+		if pod.Spec.NodeName == nodeName {
+			onNode = append(onNode, pod)
+		}
+	}
+	return onNode
+}
+
+// removePod returns pods with target (matched by namespace/name) removed, preserving order.
+func removePod(pods []*v1.Pod, target *v1.Pod) []*v1.Pod {
+	for i, pod := range pods {
+		if pod.Namespace == target.Namespace && pod.Name == target.Name {
+			return append(pods[:i], pods[i+1:]...)
+		}
+	}
+	return pods
+}
+
+// nodeTopologyLabels returns the map[string]string of topology-key to value (i.e. the node's labels)
+// for the node with the given name, or nil if no such node is found in nodes.
+func nodeTopologyLabels(nodeName string, nodes []*v1.Node) map[string]string {
+	node := nodeByName(nodeName, nodes)
+	if node == nil {
+		return nil
+	}
+	return node.Labels
+}
+
+// nodeByName returns the node with the given name from nodes, or nil if no such node is found.
+func nodeByName(nodeName string, nodes []*v1.Node) *v1.Node {
+	for _, node := range nodes {
+		if node.Name == nodeName {
+			return node
+		}
+	}
+	return nil
+}
+
 // getPodAntiAffinityTerms gets the antiaffinity terms for the given pod.
 func getPodAntiAffinityTerms(podAntiAffinity *v1.PodAntiAffinity) (terms []v1.PodAffinityTerm) {
 	if podAntiAffinity != nil {
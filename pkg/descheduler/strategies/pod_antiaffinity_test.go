@@ -0,0 +1,318 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func labeledTestNamespace(name string, labels map[string]string) *v1.Namespace {
+	return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+const zoneKey = "topology.kubernetes.io/zone"
+
+func zoneTestNode(name, zone string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{zoneKey: zone},
+		},
+	}
+}
+
+func antiAffinityTestPod(name, namespace, nodeName string, labels map[string]string, antiAffinityLabels map[string]string, topologyKey string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Affinity: &v1.Affinity{
+				PodAntiAffinity: &v1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: antiAffinityLabels},
+							TopologyKey:   topologyKey,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// TestCheckPodsWithAntiAffinityExistCrossNode verifies that two pods on different nodes within the same
+// topology domain are detected as conflicting, and that pods in different topology domains are not -
+// the behavior the per-node-only candidate list used to get wrong for any TopologyKey other than
+// kubernetes.io/hostname.
+func TestCheckPodsWithAntiAffinityExistCrossNode(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeAZone string
+		podBZone  string
+		wantEvict bool
+	}{
+		{
+			name:      "same zone, different nodes: conflict",
+			nodeAZone: "zone-a",
+			podBZone:  "zone-a",
+			wantEvict: true,
+		},
+		{
+			name:      "different zones: no conflict",
+			nodeAZone: "zone-a",
+			podBZone:  "zone-b",
+			wantEvict: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nodeA := zoneTestNode("node-a", tc.nodeAZone)
+			nodeB := zoneTestNode("node-b", tc.podBZone)
+			nodes := []*v1.Node{nodeA, nodeB}
+
+			podA := antiAffinityTestPod("pod-a", "default", "node-a", map[string]string{"app": "foo"}, map[string]string{"app": "foo"}, zoneKey)
+			podB := antiAffinityTestPod("pod-b", "default", "node-b", map[string]string{"app": "foo"}, map[string]string{"app": "foo"}, zoneKey)
+
+			client := fake.NewSimpleClientset()
+			allPods := []*v1.Pod{podA, podB}
+
+			got := checkPodsWithAntiAffinityExist(context.TODO(), client, podA, allPods, nodes, false, map[string]sets.String{}, nil)
+			if got != tc.wantEvict {
+				t.Errorf("checkPodsWithAntiAffinityExist() = %v, want %v", got, tc.wantEvict)
+			}
+		})
+	}
+}
+
+// TestCheckPodsWithAntiAffinityExistSingleNodeCandidatesMiss documents the bug that motivated gathering
+// allPods cluster-wide: if the candidate list only contains pods from pod's own node, a same-zone
+// violation on a different node is invisible.
+func TestCheckPodsWithAntiAffinityExistSingleNodeCandidatesMiss(t *testing.T) {
+	nodeA := zoneTestNode("node-a", "zone-a")
+	nodeB := zoneTestNode("node-b", "zone-a")
+	nodes := []*v1.Node{nodeA, nodeB}
+
+	podA := antiAffinityTestPod("pod-a", "default", "node-a", map[string]string{"app": "foo"}, map[string]string{"app": "foo"}, zoneKey)
+	podB := antiAffinityTestPod("pod-b", "default", "node-b", map[string]string{"app": "foo"}, map[string]string{"app": "foo"}, zoneKey)
+
+	client := fake.NewSimpleClientset()
+
+	// Candidates limited to node-a's own pods (the old, buggy behavior) never see podB.
+	onlyNodeAPods := podsOnNode("node-a", []*v1.Pod{podA, podB})
+	if got := checkPodsWithAntiAffinityExist(context.TODO(), client, podA, onlyNodeAPods, nodes, false, map[string]sets.String{}, nil); got {
+		t.Fatalf("expected no violation visible from a single-node candidate list, got true")
+	}
+
+	// Candidates spanning the cluster correctly find the same-zone conflict.
+	allPods := []*v1.Pod{podA, podB}
+	if got := checkPodsWithAntiAffinityExist(context.TODO(), client, podA, allPods, nodes, false, map[string]sets.String{}, nil); !got {
+		t.Fatalf("expected cross-node conflict to be detected with cluster-wide candidates, got false")
+	}
+}
+
+func TestPodsOnNode(t *testing.T) {
+	podA := antiAffinityTestPod("pod-a", "default", "node-a", nil, nil, zoneKey)
+	podB := antiAffinityTestPod("pod-b", "default", "node-b", nil, nil, zoneKey)
+
+	got := podsOnNode("node-a", []*v1.Pod{podA, podB})
+	if len(got) != 1 || got[0].Name != "pod-a" {
+		t.Fatalf("podsOnNode() = %v, want only pod-a", got)
+	}
+}
+
+func TestRemovePod(t *testing.T) {
+	podA := antiAffinityTestPod("pod-a", "default", "node-a", nil, nil, zoneKey)
+	podB := antiAffinityTestPod("pod-b", "default", "node-b", nil, nil, zoneKey)
+
+	got := removePod([]*v1.Pod{podA, podB}, podA)
+	if len(got) != 1 || got[0].Name != "pod-b" {
+		t.Fatalf("removePod() = %v, want only pod-b", got)
+	}
+}
+
+func preferredAntiAffinityTestPod(name, namespace, nodeName string, terms []v1.WeightedPodAffinityTerm) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Affinity: &v1.Affinity{
+				PodAntiAffinity: &v1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: terms,
+				},
+			},
+		},
+	}
+}
+
+func weightedAntiAffinityTerm(weight int32, matchLabels map[string]string, topologyKey string) v1.WeightedPodAffinityTerm {
+	return v1.WeightedPodAffinityTerm{
+		Weight: weight,
+		PodAffinityTerm: v1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: matchLabels},
+			TopologyKey:   topologyKey,
+		},
+	}
+}
+
+// TestCheckPodsWithAntiAffinityExistPreferredWeightThreshold verifies that a pod is only flagged for
+// eviction via its preferred anti-affinity terms once the sum of violated terms' weights meets or exceeds
+// the configured threshold, and that below the threshold it is left alone.
+func TestCheckPodsWithAntiAffinityExistPreferredWeightThreshold(t *testing.T) {
+	node := zoneTestNode("node-a", "zone-a")
+	nodes := []*v1.Node{node}
+
+	pod := preferredAntiAffinityTestPod("pod-a", "default", "node-a", []v1.WeightedPodAffinityTerm{
+		weightedAntiAffinityTerm(30, map[string]string{"app": "foo"}, zoneKey),
+		weightedAntiAffinityTerm(40, map[string]string{"app": "bar"}, zoneKey),
+	})
+	conflictingFoo := antiAffinityTestPod("pod-foo", "default", "node-a", map[string]string{"app": "foo"}, nil, "")
+	conflictingBar := antiAffinityTestPod("pod-bar", "default", "node-a", map[string]string{"app": "bar"}, nil, "")
+
+	client := fake.NewSimpleClientset()
+
+	tests := []struct {
+		name       string
+		candidates []*v1.Pod
+		threshold  int32
+		want       bool
+	}{
+		{
+			name:       "single violated term below threshold: not evicted",
+			candidates: []*v1.Pod{conflictingFoo},
+			threshold:  50,
+			want:       false,
+		},
+		{
+			name:       "both terms violated, sum meets threshold: evicted",
+			candidates: []*v1.Pod{conflictingFoo, conflictingBar},
+			threshold:  50,
+			want:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			threshold := tc.threshold
+			got := checkPodsWithAntiAffinityExist(context.TODO(), client, pod, tc.candidates, nodes, false, map[string]sets.String{}, &threshold)
+			if got != tc.want {
+				t.Errorf("checkPodsWithAntiAffinityExist() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestPodsConflictCrossNode verifies that the vertex-cover conflict check used to build
+// evictMinimumAntiAffinityCover's graph correctly detects a conflict between two pods on different nodes
+// that share the same topology-key value. evictMinimumAntiAffinityCover used to build its graph from a
+// single node's pods at a time, so this exact conflict - the scenario MinimizeEvictions exists to resolve
+// after a scale-down - was never represented as an edge and nothing was ever evicted for it.
+func TestPodsConflictCrossNode(t *testing.T) {
+	nodeA := zoneTestNode("node-a", "zone-a")
+	nodeB := zoneTestNode("node-b", "zone-a")
+	nodes := []*v1.Node{nodeA, nodeB}
+
+	podA := antiAffinityTestPod("pod-a", "default", "node-a", map[string]string{"app": "foo"}, map[string]string{"app": "foo"}, zoneKey)
+	podB := antiAffinityTestPod("pod-b", "default", "node-b", map[string]string{"app": "foo"}, map[string]string{"app": "foo"}, zoneKey)
+
+	client := fake.NewSimpleClientset()
+	if !podsConflict(context.TODO(), client, podA, podB, nodes, false, map[string]sets.String{}, nil) {
+		t.Fatalf("expected pod-a and pod-b to conflict across nodes in the same zone")
+	}
+}
+
+// TestCountGreedyEvictions verifies the greedy-simulation helper used purely for MinimizeEvictions'
+// savings metric: a single conflict between two pods on different nodes in the same zone should count
+// exactly one eviction, against the cluster-wide pods slice.
+func TestCountGreedyEvictions(t *testing.T) {
+	nodeA := zoneTestNode("node-a", "zone-a")
+	nodeB := zoneTestNode("node-b", "zone-a")
+	nodes := []*v1.Node{nodeA, nodeB}
+
+	podA := antiAffinityTestPod("pod-a", "default", "node-a", map[string]string{"app": "foo"}, map[string]string{"app": "foo"}, zoneKey)
+	podB := antiAffinityTestPod("pod-b", "default", "node-b", map[string]string{"app": "foo"}, map[string]string{"app": "foo"}, zoneKey)
+
+	client := fake.NewSimpleClientset()
+	pods := []*v1.Pod{podA, podB}
+	if got := countGreedyEvictions(context.TODO(), client, pods, nodes, false, map[string]sets.String{}, nil); got != 1 {
+		t.Errorf("countGreedyEvictions() = %d, want 1", got)
+	}
+}
+
+// TestCheckPodsWithAntiAffinityExistNamespaceSelector exercises resolveNamespacesBySelector end-to-end:
+// term.NamespaceSelector is resolved against namespaces seeded on a fake clientset, and
+// ignorePodAffinityNamespaceSelector=true is verified to opt back out to the "default to pod's own
+// namespace" behavior instead of consulting the selector at all.
+func TestCheckPodsWithAntiAffinityExistNamespaceSelector(t *testing.T) {
+	node := zoneTestNode("node-a", "zone-a")
+	nodes := []*v1.Node{node}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "pod-ns"},
+		Spec: v1.PodSpec{
+			NodeName: "node-a",
+			Affinity: &v1.Affinity{
+				PodAntiAffinity: &v1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+						{
+							LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+							TopologyKey:       zoneKey,
+						},
+					},
+				},
+			},
+		},
+	}
+	candidate := antiAffinityTestPod("pod-b", "team-a-ns", "node-a", map[string]string{"app": "foo"}, nil, "")
+	candidates := []*v1.Pod{candidate}
+
+	client := fake.NewSimpleClientset(labeledTestNamespace("team-a-ns", map[string]string{"team": "a"}))
+
+	t.Run("NamespaceSelector resolved against the cluster: violation detected", func(t *testing.T) {
+		got := checkPodsWithAntiAffinityExist(context.TODO(), client, pod, candidates, nodes, false, map[string]sets.String{}, nil)
+		if !got {
+			t.Fatalf("expected a violation once NamespaceSelector resolves to team-a-ns")
+		}
+	})
+
+	t.Run("ignorePodAffinityNamespaceSelector=true: falls back to pod's own namespace, no violation", func(t *testing.T) {
+		got := checkPodsWithAntiAffinityExist(context.TODO(), client, pod, candidates, nodes, true, map[string]sets.String{}, nil)
+		if got {
+			t.Fatalf("expected no violation once NamespaceSelector is ignored and the term defaults to pod-ns")
+		}
+	})
+}
+
+func TestNodeByName(t *testing.T) {
+	nodeA := zoneTestNode("node-a", "zone-a")
+	nodeB := zoneTestNode("node-b", "zone-b")
+	nodes := []*v1.Node{nodeA, nodeB}
+
+	if got := nodeByName("node-b", nodes); got != nodeB {
+		t.Errorf("nodeByName(%q) = %v, want node-b", "node-b", got)
+	}
+	if got := nodeByName("node-c", nodes); got != nil {
+		t.Errorf("nodeByName(%q) = %v, want nil", "node-c", got)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+// maxExactVertexCoverSize bounds how many vertices a conflict graph may have before the exact
+// branch-and-bound solver is skipped in favor of the maximal-matching 2-approximation. The vertex count
+// evictMinimumAntiAffinityCover passes is every pod it considers cluster-wide, including pods with no
+// conflicts, so the exact solver runs only when that pod count is at most this value. exactMinVertexCover prunes
+// branches using a matching-based lower bound, but a dense enough graph at this size can still branch
+// combinatorially (worst case exponential in the vertex count); this threshold trades that worst case
+// against how suboptimal the 2-approximation's cover may be above it.
+const maxExactVertexCoverSize = 12
+
+// vertexCoverEdge is an undirected edge between two conflict-graph vertex indices.
+type vertexCoverEdge struct {
+	u, v int
+}
+
+// minWeightVertexCover returns the indices of a minimum (or, above maxExactVertexCoverSize vertices,
+// approximately minimum) vertex cover of the graph described by edges over n vertices. weight is used as
+// a tie-breaker between equally-sized covers, preferring covers with a lower total weight (e.g. lower pod
+// priority) when more than one minimum cover exists. weight is used only by the exact solver; the
+// approximate cover above maxExactVertexCoverSize vertices ignores it.
+func minWeightVertexCover(n int, edges []vertexCoverEdge, weight func(v int) int64) []int {
+	if len(edges) == 0 {
+		return nil
+	}
+	if n <= maxExactVertexCoverSize {
+		return exactMinVertexCover(n, edges, weight)
+	}
+	return approximateVertexCover(edges)
+}
+
+// exactMinVertexCover finds a minimum vertex cover via branch-and-bound: repeatedly pick an edge not yet
+// covered and branch on including each of its two endpoints, pruning a branch as soon as its partial cover
+// plus a lower bound on the covers still needed is larger than the best complete cover found so far.
+// Branches that can only tie the best size are still explored, so that weight can break the tie.
+// The lower bound keeps this from degrading to the full O(2^n) search on dense graphs, but a sufficiently
+// adversarial conflict graph up to maxExactVertexCoverSize vertices can still take exponential time.
+func exactMinVertexCover(n int, edges []vertexCoverEdge, weight func(v int) int64) []int {
+	inCover := make([]bool, n)
+	var best []int
+
+	isBetter := func(cover []int) bool {
+		if best == nil || len(cover) < len(best) {
+			return true
+		}
+		return len(cover) == len(best) && coverWeight(cover, weight) < coverWeight(best, weight)
+	}
+
+	var branch func(cover []int)
+	branch = func(cover []int) {
+		if best != nil && len(cover) > len(best) {
+			return
+		}
+		if best != nil && len(cover)+lowerBoundRemaining(edges, inCover) > len(best) {
+			return
+		}
+
+		edgeIdx := -1
+		for i, e := range edges {
+			if !inCover[e.u] && !inCover[e.v] {
+				edgeIdx = i
+				break
+			}
+		}
+		if edgeIdx == -1 {
+			if isBetter(cover) {
+				best = append([]int{}, cover...)
+			}
+			return
+		}
+
+		e := edges[edgeIdx]
+		inCover[e.u] = true
+		branch(append(cover, e.u))
+		inCover[e.u] = false
+
+		inCover[e.v] = true
+		branch(append(cover, e.v))
+		inCover[e.v] = false
+	}
+	branch(nil)
+	return best
+}
+
+// lowerBoundRemaining lower-bounds the number of additional vertices any valid cover must add to inCover,
+// by greedily finding a maximal matching among the edges inCover does not yet cover: every matched edge
+// needs at least one of its own endpoints added, and matched edges share no endpoints, so the matching's
+// size is a valid lower bound.
+func lowerBoundRemaining(edges []vertexCoverEdge, inCover []bool) int {
+	matched := make([]bool, len(inCover))
+	bound := 0
+	for _, e := range edges {
+		if inCover[e.u] || inCover[e.v] || matched[e.u] || matched[e.v] {
+			continue
+		}
+		matched[e.u] = true
+		matched[e.v] = true
+		bound++
+	}
+	return bound
+}
+
+// coverWeight sums weight(v) over the given vertex cover.
+func coverWeight(cover []int, weight func(v int) int64) int64 {
+	var total int64
+	for _, v := range cover {
+		total += weight(v)
+	}
+	return total
+}
+
+// approximateVertexCover returns a 2-approximate vertex cover computed via maximal matching: repeatedly
+// take any edge neither of whose endpoints is covered yet and add both endpoints to the cover. The
+// resulting cover is at most twice the size of a minimum vertex cover.
+func approximateVertexCover(edges []vertexCoverEdge) []int {
+	covered := make(map[int]bool)
+	var cover []int
+	for _, e := range edges {
+		if covered[e.u] || covered[e.v] {
+			continue
+		}
+		covered[e.u] = true
+		covered[e.v] = true
+		cover = append(cover, e.u, e.v)
+	}
+	return cover
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"testing"
+)
+
+func unitWeight(int) int64 { return 1 }
+
+func TestExactMinVertexCoverTriangle(t *testing.T) {
+	// A triangle (0-1, 1-2, 0-2) needs exactly 2 vertices in any cover.
+	edges := []vertexCoverEdge{{0, 1}, {1, 2}, {0, 2}}
+	cover := exactMinVertexCover(3, edges, unitWeight)
+	if len(cover) != 2 {
+		t.Fatalf("exactMinVertexCover() = %v, want a cover of size 2", cover)
+	}
+	if !coversAllEdges(edges, cover) {
+		t.Fatalf("cover %v does not cover all edges %v", cover, edges)
+	}
+}
+
+func TestExactMinVertexCoverStar(t *testing.T) {
+	// A star (center 0 connected to 1,2,3,4) needs exactly 1 vertex: the center.
+	edges := []vertexCoverEdge{{0, 1}, {0, 2}, {0, 3}, {0, 4}}
+	cover := exactMinVertexCover(5, edges, unitWeight)
+	if len(cover) != 1 || cover[0] != 0 {
+		t.Fatalf("exactMinVertexCover() = %v, want [0]", cover)
+	}
+}
+
+func TestExactMinVertexCoverPrefersLowerWeight(t *testing.T) {
+	// A single edge has two equally-sized (size 1) covers: {0} or {1}. The lower-weight vertex should win.
+	edges := []vertexCoverEdge{{0, 1}}
+	weight := func(v int) int64 {
+		if v == 0 {
+			return 10
+		}
+		return 1
+	}
+	cover := exactMinVertexCover(2, edges, weight)
+	if len(cover) != 1 || cover[0] != 1 {
+		t.Fatalf("exactMinVertexCover() = %v, want [1] (lower weight)", cover)
+	}
+}
+
+func TestLowerBoundRemaining(t *testing.T) {
+	// Two disjoint edges: the matching-based bound should be 2.
+	edges := []vertexCoverEdge{{0, 1}, {2, 3}}
+	inCover := make([]bool, 4)
+	if got := lowerBoundRemaining(edges, inCover); got != 2 {
+		t.Errorf("lowerBoundRemaining() = %d, want 2", got)
+	}
+
+	// Once vertex 0 is in the cover, only the 2-3 edge remains uncovered.
+	inCover[0] = true
+	if got := lowerBoundRemaining(edges, inCover); got != 1 {
+		t.Errorf("lowerBoundRemaining() after covering vertex 0 = %d, want 1", got)
+	}
+}
+
+func TestMinWeightVertexCoverApproximateFallback(t *testing.T) {
+	// Force the approximate path by exceeding maxExactVertexCoverSize.
+	n := maxExactVertexCoverSize + 1
+	var edges []vertexCoverEdge
+	for i := 0; i < n-1; i++ {
+		edges = append(edges, vertexCoverEdge{i, i + 1})
+	}
+	cover := minWeightVertexCover(n, edges, unitWeight)
+	if !coversAllEdges(edges, cover) {
+		t.Fatalf("approximate cover %v does not cover all edges %v", cover, edges)
+	}
+}
+
+func coversAllEdges(edges []vertexCoverEdge, cover []int) bool {
+	in := make(map[int]bool, len(cover))
+	for _, v := range cover {
+		in[v] = true
+	}
+	for _, e := range edges {
+		if !in[e.u] && !in[e.v] {
+			return false
+		}
+	}
+	return true
+}
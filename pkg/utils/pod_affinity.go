@@ -0,0 +1,49 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// GetNamespacesFromPodAffinityTerm returns a set of names according to the namespaces indicated in
+// podAffinityTerm, unioned with selectorNamespaces (the namespaces resolved from the term's
+// NamespaceSelector, if any). hasNamespaceSelector must be true iff podAffinityTerm.NamespaceSelector was
+// set, so that an empty selectorNamespaces can be told apart from "no selector was given": only when
+// neither Namespaces nor NamespaceSelector was set does the term default to the pod's own namespace. A
+// NamespaceSelector that legitimately resolves to zero namespaces (e.g. a labeled namespace was deleted)
+// must make the term match no namespaces, not silently fall back to pod's own.
+func GetNamespacesFromPodAffinityTerm(pod *v1.Pod, podAffinityTerm *v1.PodAffinityTerm, selectorNamespaces sets.String, hasNamespaceSelector bool) sets.String {
+	if len(podAffinityTerm.Namespaces) == 0 && !hasNamespaceSelector {
+		return sets.NewString(pod.Namespace)
+	}
+	names := sets.NewString(podAffinityTerm.Namespaces...)
+	names = names.Union(selectorNamespaces)
+	return names
+}
+
+// PodMatchesTermsNamespaceAndSelector returns true if the given pod matches the namespace and selector
+// defined by a PodAffinityTerm. namespaces is the concrete set of namespaces the term applies to, as
+// returned by GetNamespacesFromPodAffinityTerm: an empty namespaces means the term matches nothing.
+func PodMatchesTermsNamespaceAndSelector(pod *v1.Pod, namespaces sets.String, selector labels.Selector) bool {
+	if !namespaces.Has(pod.Namespace) {
+		return false
+	}
+	return selector.Matches(labels.Set(pod.Labels))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestGetNamespacesFromPodAffinityTerm(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "pod-ns"}}
+
+	tests := []struct {
+		name                 string
+		termNamespaces       []string
+		selectorNamespaces   sets.String
+		hasNamespaceSelector bool
+		want                 sets.String
+	}{
+		{
+			name: "neither Namespaces nor NamespaceSelector set: defaults to pod's own namespace",
+			want: sets.NewString("pod-ns"),
+		},
+		{
+			name:           "Namespaces set explicitly",
+			termNamespaces: []string{"a", "b"},
+			want:           sets.NewString("a", "b"),
+		},
+		{
+			name:                 "NamespaceSelector set and resolves to namespaces",
+			hasNamespaceSelector: true,
+			selectorNamespaces:   sets.NewString("c", "d"),
+			want:                 sets.NewString("c", "d"),
+		},
+		{
+			name:                 "NamespaceSelector set but resolves to zero namespaces: matches none, not pod's own",
+			hasNamespaceSelector: true,
+			selectorNamespaces:   sets.NewString(),
+			want:                 sets.NewString(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			term := &v1.PodAffinityTerm{Namespaces: tc.termNamespaces}
+			got := GetNamespacesFromPodAffinityTerm(pod, term, tc.selectorNamespaces, tc.hasNamespaceSelector)
+			if !got.Equal(tc.want) {
+				t.Errorf("GetNamespacesFromPodAffinityTerm() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPodMatchesTermsNamespaceAndSelector(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "pod-ns", Labels: map[string]string{"app": "foo"}}}
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}})
+	if err != nil {
+		t.Fatalf("failed to build selector: %v", err)
+	}
+
+	if PodMatchesTermsNamespaceAndSelector(pod, sets.NewString(), selector) {
+		t.Errorf("expected no match against an empty namespace set")
+	}
+	if !PodMatchesTermsNamespaceAndSelector(pod, sets.NewString("pod-ns"), selector) {
+		t.Errorf("expected match when namespace and labels both match")
+	}
+	if PodMatchesTermsNamespaceAndSelector(pod, sets.NewString("other-ns"), selector) {
+		t.Errorf("expected no match when namespace does not match")
+	}
+}